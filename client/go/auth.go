@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// AuthProvider applies credentials to an outgoing request
+type AuthProvider interface {
+	Apply(req *http.Request) error
+}
+
+// TokenRefresher is implemented by AuthProviders that can obtain a new
+// credential after a 401, such as BearerToken
+type TokenRefresher interface {
+	Refresh(c *Client) error
+}
+
+// NoAuth sends requests without any credentials
+type NoAuth struct{}
+
+// Apply is a no-op for NoAuth
+func (NoAuth) Apply(req *http.Request) error { return nil }
+
+// StaticAPIKey sends a fixed API key on every request
+type StaticAPIKey struct {
+	Key string
+}
+
+// Apply sets the X-API-Key header
+func (a StaticAPIKey) Apply(req *http.Request) error {
+	req.Header.Set("X-API-Key", a.Key)
+	return nil
+}
+
+// BearerToken reads an access token from TokenFile and sends it as a Bearer
+// token, refreshing it from the server's /api/auth/token endpoint on 401
+type BearerToken struct {
+	TokenFile string
+
+	mu    sync.Mutex
+	token string
+}
+
+// Apply sets the Authorization header, loading the token from disk on first use
+func (b *BearerToken) Apply(req *http.Request) error {
+	token, err := b.currentToken()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (b *BearerToken) currentToken() (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.token != "" {
+		return b.token, nil
+	}
+
+	data, err := os.ReadFile(b.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file %s: %w", b.TokenFile, err)
+	}
+	b.token = strings.TrimSpace(string(data))
+	return b.token, nil
+}
+
+// Refresh exchanges the current token for a new one via POST /api/auth/token
+// and persists it back to TokenFile
+func (b *BearerToken) Refresh(c *Client) error {
+	current, _ := b.currentToken()
+
+	jsonData, err := json.Marshal(map[string]string{"token": current})
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/auth/token", "application/json", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Success bool   `json:"success"`
+		Token   string `json:"token"`
+		Error   string `json:"error,omitempty"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode refresh response: %w", err)
+	}
+	if !body.Success {
+		return fmt.Errorf("token refresh failed: %s", body.Error)
+	}
+
+	b.mu.Lock()
+	b.token = body.Token
+	b.mu.Unlock()
+
+	if err := os.WriteFile(b.TokenFile, []byte(body.Token), 0600); err != nil {
+		return fmt.Errorf("failed to persist refreshed token: %w", err)
+	}
+
+	return nil
+}
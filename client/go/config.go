@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ProfileConfig holds the server and credentials for one named profile in
+// the config file
+type ProfileConfig struct {
+	Server    string `yaml:"server"`
+	APIKey    string `yaml:"api_key,omitempty"`
+	TokenFile string `yaml:"token_file,omitempty"`
+}
+
+// CLIConfig is the on-disk shape of ~/.mmc/config.yaml: multiple server
+// profiles, selectable with -profile
+type CLIConfig struct {
+	Profiles map[string]ProfileConfig `yaml:"profiles"`
+}
+
+// loadCLIConfig reads and parses a config file. Reading is skipped (no
+// error) when path is empty or the file does not exist, since -config is optional.
+func loadCLIConfig(path string) (*CLIConfig, error) {
+	if path == "" {
+		return &CLIConfig{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &CLIConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	var cfg CLIConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Profile looks up a named profile, returning an error if it is not defined
+func (c *CLIConfig) Profile(name string) (ProfileConfig, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return ProfileConfig{}, fmt.Errorf("profile %q not found in config", name)
+	}
+	return profile, nil
+}
+
+// defaultConfigPath returns ~/.mmc/config.yaml, or "" if the home directory
+// cannot be determined
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return home + "/.mmc/config.yaml"
+}
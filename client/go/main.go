@@ -1,18 +1,29 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
+	"os/signal"
 	"os/user"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"text/tabwriter"
 	"time"
+
+	"github.com/cheggaaa/pb"
+	"github.com/peterzheng98/toy-model-management/client/go/metrics"
+	"golang.org/x/term"
 )
 
 const (
@@ -42,66 +53,305 @@ type ModelStats struct {
 	FirstDownloadedFrom string `json:"first_downloaded_from,omitempty"`
 }
 
+// SystemStats represents overall system statistics
+type SystemStats struct {
+	TotalModels    int           `json:"total_models"`
+	TotalSizeBytes int64         `json:"total_size_bytes"`
+	TotalRequests  int           `json:"total_requests"`
+	UniqueUsers    int           `json:"unique_users"`
+	RecentActivity []ActivityLog `json:"recent_activity,omitempty"`
+}
+
+// ActivityLog represents an activity log entry
+type ActivityLog struct {
+	Timestamp string `json:"timestamp"`
+	Action    string `json:"action"`
+	ModelID   string `json:"model_id,omitempty"`
+	Username  string `json:"username"`
+	IPAddress string `json:"ip_address"`
+}
+
 // APIResponse represents a generic API response
 type APIResponse struct {
-	Success       bool    `json:"success"`
-	Message       string  `json:"message,omitempty"`
-	Error         string  `json:"error,omitempty"`
-	Models        []Model `json:"models,omitempty"`
-	Model         *Model  `json:"model,omitempty"`
-	AlreadyExists bool    `json:"already_exists,omitempty"`
+	Success       bool         `json:"success"`
+	Message       string       `json:"message,omitempty"`
+	Error         string       `json:"error,omitempty"`
+	Models        []Model      `json:"models,omitempty"`
+	Model         *Model       `json:"model,omitempty"`
+	Stats         *SystemStats `json:"stats,omitempty"`
+	AlreadyExists bool         `json:"already_exists,omitempty"`
+}
+
+// RetryPolicy controls how doJSON retries failed requests
+type RetryPolicy struct {
+	MaxRetries int           // 0 disables retries, unless BaseDelay is also 0 (see NewClientWithOptions)
+	BaseDelay  time.Duration // doubled on each successive retry
+}
+
+// defaultRetryPolicy backs off up to 3 times on 5xx/network errors
+var defaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond}
+
+// ClientOptions configures NewClientWithOptions
+type ClientOptions struct {
+	BaseURL     string
+	Auth        AuthProvider // defaults to NoAuth
+	Timeout     time.Duration
+	RetryPolicy RetryPolicy
+	UserAgent   string
 }
 
 // Client is the HTTP client for the model management server
 type Client struct {
-	BaseURL    string
-	HTTPClient *http.Client
+	BaseURL     string
+	HTTPClient  *http.Client
+	Auth        AuthProvider
+	RetryPolicy RetryPolicy
+	UserAgent   string
+
+	// lastJobID holds the job_id reported by the most recent DownloadModelStream
+	// call, so callers can cancel it (e.g. on SIGINT) without changing that
+	// method's signature. Guarded by lastJobIDMu since it's written from the
+	// streaming goroutine and read from the signal-handling goroutine.
+	lastJobIDMu sync.Mutex
+	lastJobID   string
 }
 
-// NewClient creates a new client
+// LastJobID returns the job_id reported by the most recent DownloadModelStream call
+func (c *Client) LastJobID() string {
+	c.lastJobIDMu.Lock()
+	defer c.lastJobIDMu.Unlock()
+	return c.lastJobID
+}
+
+// setLastJobID records the job_id reported by the most recent DownloadModelStream call
+func (c *Client) setLastJobID(jobID string) {
+	c.lastJobIDMu.Lock()
+	defer c.lastJobIDMu.Unlock()
+	c.lastJobID = jobID
+}
+
+// NewClient creates a new unauthenticated client, equivalent to
+// NewClientWithOptions(ClientOptions{BaseURL: baseURL})
 func NewClient(baseURL string) *Client {
+	return NewClientWithOptions(ClientOptions{BaseURL: baseURL})
+}
+
+// NewClientWithOptions creates a client with explicit auth, timeout, and retry behavior
+func NewClientWithOptions(opts ClientOptions) *Client {
+	baseURL := opts.BaseURL
 	if baseURL == "" {
 		baseURL = defaultServerURL
 	}
+
+	auth := opts.Auth
+	if auth == nil {
+		auth = NoAuth{}
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute // Long default for downloads
+	}
+
+	// An all-zero RetryPolicy means "not set" and gets defaultRetryPolicy
+	retryPolicy := opts.RetryPolicy
+	if retryPolicy.MaxRetries == 0 && retryPolicy.BaseDelay == 0 {
+		retryPolicy = defaultRetryPolicy
+	}
+
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = "mmc/1.0"
+	}
+
 	return &Client{
-		BaseURL: baseURL,
-		HTTPClient: &http.Client{
-			Timeout: 5 * time.Minute, // Long timeout for downloads
-		},
+		BaseURL:     baseURL,
+		HTTPClient:  &http.Client{Timeout: timeout},
+		Auth:        auth,
+		RetryPolicy: retryPolicy,
+		UserAgent:   userAgent,
 	}
 }
 
-// ListModels retrieves all models from the server
-func (c *Client) ListModels() ([]Model, error) {
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/api/models")
-	if err != nil {
+// jsonRequest describes a request to be built and executed by doJSON
+type jsonRequest struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{} // marshaled as the JSON request body if non-nil
+}
+
+// doJSON centralizes request building, auth injection, 401-refresh-retry,
+// and exponential-backoff retry on 5xx/network errors. out is decoded from
+// the response body on success; pass nil to discard the body.
+func (c *Client) doJSON(r jsonRequest, out interface{}) error {
+	reqURL := c.BaseURL + r.path
+	if len(r.query) > 0 {
+		reqURL += "?" + r.query.Encode()
+	}
+
+	var bodyBytes []byte
+	if r.body != nil {
+		marshaled, err := json.Marshal(r.body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		bodyBytes = marshaled
+	}
+
+	refreshed := false
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(r.method, reqURL, bytes.NewReader(bodyBytes))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		if bodyBytes != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("User-Agent", c.UserAgent)
+		if err := c.Auth.Apply(req); err != nil {
+			return fmt.Errorf("failed to apply auth: %w", err)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if attempt < c.RetryPolicy.MaxRetries {
+				time.Sleep(c.RetryPolicy.BaseDelay << attempt)
+				continue
+			}
+			return fmt.Errorf("request failed: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && !refreshed {
+			resp.Body.Close()
+			if refresher, ok := c.Auth.(TokenRefresher); ok {
+				refreshed = true
+				if err := refresher.Refresh(c); err != nil {
+					return fmt.Errorf("failed to refresh credentials: %w", err)
+				}
+				continue
+			}
+		}
+
+		if resp.StatusCode >= 500 && attempt < c.RetryPolicy.MaxRetries {
+			resp.Body.Close()
+			time.Sleep(c.RetryPolicy.BaseDelay << attempt)
+			continue
+		}
+
+		defer resp.Body.Close()
+		if out == nil {
+			return nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	}
+}
+
+// ListModelsOptions controls pagination, filtering, and sorting for ListModelsPage
+type ListModelsOptions struct {
+	Page         int    // 1-based; 0 means "use the server default"
+	PageSize     int    // 0 means "use the server default"
+	Sort         string // e.g. "-downloaded_at", "size_bytes", "download_count"
+	Status       string
+	NameContains string
+	DownloadedBy string
+}
+
+// ListModelsPage is one page of a paginated /api/models response
+type ListModelsPage struct {
+	Success    bool    `json:"success"`
+	Error      string  `json:"error,omitempty"`
+	Models     []Model `json:"models"`
+	Page       int     `json:"page"`
+	PageSize   int     `json:"page_size"`
+	Total      int     `json:"total"`
+	TotalPages int     `json:"total_pages"`
+}
+
+// ListModelsPage retrieves a single page of models matching the given options
+func (c *Client) ListModelsPage(opts ListModelsOptions) (*ListModelsPage, error) {
+	query := url.Values{}
+	if opts.Page > 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(opts.PageSize))
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	if opts.NameContains != "" {
+		query.Set("name_contains", opts.NameContains)
+	}
+	if opts.DownloadedBy != "" {
+		query.Set("downloaded_by", opts.DownloadedBy)
+	}
+
+	var page ListModelsPage
+	if err := c.doJSON(jsonRequest{method: "GET", path: "/api/models", query: query}, &page); err != nil {
 		return nil, fmt.Errorf("failed to list models: %w", err)
 	}
-	defer resp.Body.Close()
 
-	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if !page.Success {
+		return nil, fmt.Errorf("API error: %s", page.Error)
 	}
 
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Error)
+	return &page, nil
+}
+
+// ListModels retrieves all models from the server, transparently paging
+// through the full result set. Use ListModelsPage directly when you only
+// need one page, e.g. to keep a large registry browsable.
+func (c *Client) ListModels() ([]Model, error) {
+	var models []Model
+	opts := ListModelsOptions{Page: 1, PageSize: 100}
+
+	for {
+		page, err := c.ListModelsPage(opts)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, page.Models...)
+		if len(page.Models) == 0 || page.Page >= page.TotalPages {
+			break
+		}
+		opts.Page = page.Page + 1
 	}
 
-	return apiResp.Models, nil
+	return models, nil
 }
 
 // GetModel retrieves a specific model by ID
 func (c *Client) GetModel(modelID string) (*Model, error) {
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/api/models/" + modelID)
-	if err != nil {
+	var apiResp APIResponse
+	if err := c.doJSON(jsonRequest{method: "GET", path: "/api/models/" + modelID}, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to get model: %w", err)
 	}
-	defer resp.Body.Close()
+
+	if !apiResp.Success {
+		return nil, fmt.Errorf("API error: %s", apiResp.Error)
+	}
+
+	return apiResp.Model, nil
+}
+
+// DownloadModel requests the server to download a model from Hugging Face
+func (c *Client) DownloadModel(modelName, username string) (*Model, error) {
+	payload := map[string]string{
+		"model_name": modelName,
+		"username":   username,
+	}
 
 	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(jsonRequest{method: "POST", path: "/api/models/download", body: payload}, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to download model: %w", err)
 	}
 
 	if !apiResp.Success {
@@ -111,8 +361,21 @@ func (c *Client) GetModel(modelID string) (*Model, error) {
 	return apiResp.Model, nil
 }
 
-// DownloadModel requests the server to download a model from Hugging Face
-func (c *Client) DownloadModel(modelName, username string) (*Model, error) {
+// DownloadEvent represents a single line of the download/stream response
+type DownloadEvent struct {
+	Event      string `json:"event"`
+	JobID      string `json:"job_id,omitempty"`
+	BytesDone  int64  `json:"bytes_done,omitempty"`
+	BytesTotal int64  `json:"bytes_total,omitempty"`
+	File       string `json:"file,omitempty"`
+	Model      *Model `json:"model,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// DownloadModelStream requests the server to download a model from Hugging Face,
+// streaming newline-delimited JSON progress events as the transfer proceeds.
+// onProgress is invoked for every "progress" event; it may be nil.
+func (c *Client) DownloadModelStream(modelName, username string, onProgress func(done, total int64, file string)) (*Model, error) {
 	payload := map[string]string{
 		"model_name": modelName,
 		"username":   username,
@@ -123,44 +386,77 @@ func (c *Client) DownloadModel(modelName, username string) (*Model, error) {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/models/download",
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/models/download/stream", bytes.NewReader(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to download model: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	if err := c.Auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start streaming download: %w", err)
 	}
 	defer resp.Body.Close()
 
-	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "data:"))
+		if line == "" {
+			continue
+		}
+
+		var event DownloadEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode download event: %w", err)
+		}
+
+		switch event.Event {
+		case "progress":
+			if onProgress != nil {
+				onProgress(event.BytesDone, event.BytesTotal, event.File)
+			}
+			if event.JobID != "" {
+				c.setLastJobID(event.JobID)
+			}
+		case "done":
+			return event.Model, nil
+		case "error":
+			return nil, fmt.Errorf("download failed: %s", event.Error)
+		}
 	}
 
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Error)
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read download stream: %w", err)
 	}
 
-	return apiResp.Model, nil
+	return nil, fmt.Errorf("download stream ended without a done event")
 }
 
-// DeleteModel deletes a model from the server
-func (c *Client) DeleteModel(modelID string) error {
-	req, err := http.NewRequest("DELETE", c.BaseURL+"/api/models/"+modelID, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// CancelDownload asks the server to abort an in-flight streaming download job
+func (c *Client) CancelDownload(jobID string) error {
+	var apiResp APIResponse
+	if err := c.doJSON(jsonRequest{method: "DELETE", path: "/api/models/download/" + jobID}, &apiResp); err != nil {
+		return fmt.Errorf("failed to cancel download: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to delete model: %w", err)
+	if !apiResp.Success {
+		return fmt.Errorf("API error: %s", apiResp.Error)
 	}
-	defer resp.Body.Close()
 
+	return nil
+}
+
+// DeleteModel deletes a model from the server
+func (c *Client) DeleteModel(modelID string) error {
 	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(jsonRequest{method: "DELETE", path: "/api/models/" + modelID}, &apiResp); err != nil {
+		return fmt.Errorf("failed to delete model: %w", err)
 	}
 
 	if !apiResp.Success {
@@ -170,32 +466,77 @@ func (c *Client) DeleteModel(modelID string) error {
 	return nil
 }
 
-// UpdateModel updates model metadata
-func (c *Client) UpdateModel(modelID string, updates map[string]interface{}) (*Model, error) {
-	jsonData, err := json.Marshal(updates)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+// DeletePreview describes what a DeleteModel call would free up, without
+// actually deleting anything
+type DeletePreview struct {
+	BytesFreed       int64  `json:"bytes_freed"`
+	DependentRecords int    `json:"dependent_records"`
+	LastAccess       string `json:"last_access,omitempty"`
+}
+
+// PreviewDelete asks the server what deleting a model would free, for --dry-run
+func (c *Client) PreviewDelete(modelID string) (*DeletePreview, error) {
+	var apiResp struct {
+		Success bool           `json:"success"`
+		Error   string         `json:"error,omitempty"`
+		Preview *DeletePreview `json:"preview"`
+	}
+	if err := c.doJSON(jsonRequest{method: "GET", path: "/api/models/" + modelID + "/delete-preview"}, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to preview delete: %w", err)
 	}
 
-	req, err := http.NewRequest(
-		"PUT",
-		c.BaseURL+"/api/models/"+modelID,
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if !apiResp.Success {
+		return nil, fmt.Errorf("API error: %s", apiResp.Error)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update model: %w", err)
+	return apiResp.Preview, nil
+}
+
+// BatchDeleteFilter narrows a batch delete to models matching these criteria
+type BatchDeleteFilter struct {
+	UnusedSinceDays int    `json:"unused_since_days,omitempty"`
+	Status          string `json:"status,omitempty"`
+}
+
+// BatchDeleteRequest is the payload for POST /api/models/batch-delete. Set
+// IDs for an explicit list, or Filter to select models server-side (e.g.
+// everything unused for 30+ days); the two are mutually exclusive.
+type BatchDeleteRequest struct {
+	IDs    []string           `json:"ids,omitempty"`
+	Filter *BatchDeleteFilter `json:"filter,omitempty"`
+}
+
+// BatchDeleteResult is the per-model outcome of a batch delete
+type BatchDeleteResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchDeleteModels deletes multiple models in one request, either by
+// explicit ID or by server-side filter
+func (c *Client) BatchDeleteModels(req BatchDeleteRequest) ([]BatchDeleteResult, error) {
+	var apiResp struct {
+		Success bool                `json:"success"`
+		Error   string              `json:"error,omitempty"`
+		Results []BatchDeleteResult `json:"results"`
+	}
+	if err := c.doJSON(jsonRequest{method: "POST", path: "/api/models/batch-delete", body: req}, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to batch delete models: %w", err)
 	}
-	defer resp.Body.Close()
 
+	if !apiResp.Success {
+		return nil, fmt.Errorf("API error: %s", apiResp.Error)
+	}
+
+	return apiResp.Results, nil
+}
+
+// UpdateModel updates model metadata
+func (c *Client) UpdateModel(modelID string, updates map[string]interface{}) (*Model, error) {
 	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(jsonRequest{method: "PUT", path: "/api/models/" + modelID, body: updates}, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to update model: %w", err)
 	}
 
 	if !apiResp.Success {
@@ -207,22 +548,30 @@ func (c *Client) UpdateModel(modelID string, updates map[string]interface{}) (*M
 
 // HealthCheck checks if the server is healthy
 func (c *Client) HealthCheck() error {
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/api/health")
-	if err != nil {
+	var apiResp APIResponse
+	if err := c.doJSON(jsonRequest{method: "GET", path: "/api/health"}, &apiResp); err != nil {
 		return fmt.Errorf("health check failed: %w", err)
 	}
-	defer resp.Body.Close()
 
+	if !apiResp.Success {
+		return fmt.Errorf("server unhealthy")
+	}
+
+	return nil
+}
+
+// GetStats retrieves overall system statistics from the server
+func (c *Client) GetStats() (*SystemStats, error) {
 	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(jsonRequest{method: "GET", path: "/api/stats"}, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
 
 	if !apiResp.Success {
-		return fmt.Errorf("server unhealthy")
+		return nil, fmt.Errorf("API error: %s", apiResp.Error)
 	}
 
-	return nil
+	return apiResp.Stats, nil
 }
 
 // getCurrentUsername gets the current system username
@@ -262,6 +611,252 @@ func formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.2f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// downloadModelWithProgressBar drives DownloadModelStream with a CLI progress
+// bar, cancelling the in-flight job on SIGINT before exiting.
+func downloadModelWithProgressBar(client *Client, modelName, username string) (*Model, error) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	var bar *pb.ProgressBar
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigCh:
+			fmt.Fprintln(os.Stderr, "\nInterrupted, cancelling download...")
+			if jobID := client.LastJobID(); jobID != "" {
+				if err := client.CancelDownload(jobID); err != nil {
+					fmt.Fprintf(os.Stderr, "failed to cancel download: %v\n", err)
+				}
+			}
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+	defer close(done)
+
+	model, err := client.DownloadModelStream(modelName, username, func(bytesDone, bytesTotal int64, file string) {
+		if bar == nil && bytesTotal > 0 {
+			bar = pb.New64(bytesTotal)
+			bar.SetUnits(pb.U_BYTES)
+			bar.ShowSpeed = true
+			bar.ShowTimeLeft = true
+			bar.Prefix(file)
+			bar.Start()
+		}
+		if bar != nil {
+			bar.Set64(bytesDone)
+		}
+	})
+	if bar != nil {
+		bar.Finish()
+	}
+	return model, err
+}
+
+// printSystemStats fetches and renders a one-shot snapshot of SystemStats
+func printSystemStats(client *Client) {
+	stats, err := client.GetStats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	fmt.Println("Statistics:")
+	fmt.Printf("  Total Models:   %d\n", stats.TotalModels)
+	fmt.Printf("  Total Size:     %s\n", formatBytes(stats.TotalSizeBytes))
+	fmt.Printf("  Total Requests: %d\n", stats.TotalRequests)
+	fmt.Printf("  Unique Users:   %d\n", stats.UniqueUsers)
+
+	if len(stats.RecentActivity) > 0 {
+		fmt.Printf("\n  Recent Activity (last %d):\n", len(stats.RecentActivity))
+		for _, activity := range stats.RecentActivity {
+			fmt.Printf("    - %s by %s from %s\n", activity.Action, activity.Username, activity.IPAddress)
+		}
+	}
+}
+
+// runMetricsWatch repeatedly evaluates promQL and renders the result as a
+// live-updating table, with an ASCII sparkline for matrix (range) results.
+func runMetricsWatch(client *metrics.Client, promQL string, interval time.Duration) {
+	ctx := context.Background()
+
+	for {
+		end := time.Now()
+		start := end.Add(-5 * time.Minute)
+		result, err := client.QueryMetricsRange(ctx, promQL, start, end, interval)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			time.Sleep(interval)
+			continue
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "METRIC\tLATEST\tSPARKLINE")
+		for _, series := range result.Matrix {
+			if len(series.Values) == 0 {
+				continue
+			}
+			latest := series.Values[len(series.Values)-1]
+			fmt.Fprintf(w, "%v\t%.4g\t%s\n", series.Metric, latest.Value, metrics.Sparkline(series.Values))
+		}
+		w.Flush()
+
+		time.Sleep(interval)
+	}
+}
+
+// clientFromFlags resolves the -server/-api-key/-token-file/-config/-profile
+// flags into a ready-to-use Client. A -profile takes its server and
+// credentials from -config, with -api-key/-token-file taking precedence if
+// also given explicitly.
+func clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile string) (*Client, error) {
+	if profile != "" {
+		cfg, err := loadCLIConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+		p, err := cfg.Profile(profile)
+		if err != nil {
+			return nil, err
+		}
+		if serverURL == "" || serverURL == defaultServerURL {
+			serverURL = p.Server
+		}
+		if apiKey == "" && tokenFile == "" {
+			apiKey = p.APIKey
+			tokenFile = p.TokenFile
+		}
+	}
+
+	var auth AuthProvider = NoAuth{}
+	switch {
+	case apiKey != "":
+		auth = StaticAPIKey{Key: apiKey}
+	case tokenFile != "":
+		auth = &BearerToken{TokenFile: tokenFile}
+	}
+
+	return NewClientWithOptions(ClientOptions{BaseURL: serverURL, Auth: auth}), nil
+}
+
+// confirmDelete reports whether a delete should proceed. An explicit -y/-yes
+// always proceeds. Otherwise, on a TTY it prompts
+// "Are you sure you want to delete "X"? (y/n)" on os.Stdin; off a TTY (cron,
+// CI, piped output, ...) there is no one to prompt, so it requires -y rather
+// than silently assuming consent.
+func confirmDelete(description string, yes bool) bool {
+	if yes {
+		return true
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Fprintln(os.Stderr, "Error: refusing to delete without confirmation in a non-interactive context; pass -y/--yes")
+		os.Exit(1)
+	}
+
+	fmt.Printf("Are you sure you want to delete %q? (y/n) ", description)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// parseUnusedSinceDays parses a duration like "30d" into a day count
+func parseUnusedSinceDays(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasSuffix(s, "d") {
+		return 0, fmt.Errorf("expected a duration like \"30d\", got %q", s)
+	}
+	return strconv.Atoi(strings.TrimSuffix(s, "d"))
+}
+
+// runSingleDelete implements `client delete -id X [-dry-run] [-y]`
+func runSingleDelete(client *Client, modelID string, yes, dryRun bool) {
+	if dryRun {
+		preview, err := client.PreviewDelete(modelID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Dry run - deleting %s would free:\n", modelID)
+		fmt.Printf("  Bytes:             %s\n", formatBytes(preview.BytesFreed))
+		fmt.Printf("  Dependent records: %d\n", preview.DependentRecords)
+		if preview.LastAccess != "" {
+			fmt.Printf("  Last access:       %s\n", preview.LastAccess)
+		}
+		return
+	}
+
+	if !confirmDelete(modelID, yes) {
+		fmt.Println("Aborted.")
+		return
+	}
+
+	fmt.Printf("Deleting model: %s\n", modelID)
+	if err := client.DeleteModel(modelID); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Model deleted successfully!")
+}
+
+// runBatchDelete implements `client delete -ids a,b,c` and
+// `client delete -all -filter-unused-since 30d`
+func runBatchDelete(client *Client, ids string, all bool, unusedSince, status string, yes bool) {
+	req := BatchDeleteRequest{}
+
+	if ids != "" && all {
+		fmt.Fprintln(os.Stderr, "Error: -ids and -all cannot be combined")
+		os.Exit(1)
+	}
+	if ids != "" {
+		req.IDs = strings.Split(ids, ",")
+	}
+	if all {
+		filter := &BatchDeleteFilter{Status: status}
+		if unusedSince != "" {
+			days, err := parseUnusedSinceDays(unusedSince)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			filter.UnusedSinceDays = days
+		}
+		req.Filter = filter
+	}
+
+	description := "the selected models"
+	if len(req.IDs) > 0 {
+		description = fmt.Sprintf("%d model(s)", len(req.IDs))
+	}
+	if !confirmDelete(description, yes) {
+		fmt.Println("Aborted.")
+		return
+	}
+
+	results, err := client.BatchDeleteModels(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	bar := pb.StartNew(len(results))
+	failures := 0
+	for _, result := range results {
+		bar.Increment()
+		if !result.Success {
+			failures++
+			fmt.Fprintf(os.Stderr, "\n  %s: error - %s\n", result.ID, result.Error)
+		}
+	}
+	bar.Finish()
+
+	fmt.Printf("Deleted %d/%d models\n", len(results)-failures, len(results))
+}
+
 func main() {
 	// Define subcommands
 	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
@@ -269,19 +864,51 @@ func main() {
 	downloadCmd := flag.NewFlagSet("download", flag.ExitOnError)
 	deleteCmd := flag.NewFlagSet("delete", flag.ExitOnError)
 	healthCmd := flag.NewFlagSet("health", flag.ExitOnError)
+	statsCmd := flag.NewFlagSet("stats", flag.ExitOnError)
+	exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+	importCmd := flag.NewFlagSet("import", flag.ExitOnError)
 
-	// Server URL flag for all commands
+	// Server URL and auth flags shared by all commands
 	serverURL := ""
-	listCmd.StringVar(&serverURL, "server", defaultServerURL, "Server URL")
-	getCmd.StringVar(&serverURL, "server", defaultServerURL, "Server URL")
-	downloadCmd.StringVar(&serverURL, "server", defaultServerURL, "Server URL")
-	deleteCmd.StringVar(&serverURL, "server", defaultServerURL, "Server URL")
-	healthCmd.StringVar(&serverURL, "server", defaultServerURL, "Server URL")
+	apiKey := ""
+	tokenFile := ""
+	configPath := ""
+	profile := ""
+	for _, fs := range []*flag.FlagSet{listCmd, getCmd, downloadCmd, deleteCmd, healthCmd, statsCmd, exportCmd, importCmd} {
+		fs.StringVar(&serverURL, "server", defaultServerURL, "Server URL")
+		fs.StringVar(&apiKey, "api-key", "", "Static API key sent as X-API-Key")
+		fs.StringVar(&tokenFile, "token-file", "", "Path to a bearer token file, auto-refreshed on 401")
+		fs.StringVar(&configPath, "config", defaultConfigPath(), "Path to a multi-server config file")
+		fs.StringVar(&profile, "profile", "", "Named profile to load from -config")
+	}
 
 	// Command-specific flags
+	listPage := listCmd.Int("page", 1, "Page number to fetch")
+	listPageSize := listCmd.Int("page-size", 25, "Number of models per page")
+	listSort := listCmd.String("sort", "", "Sort key, e.g. -downloaded_at, size_bytes, download_count")
+	listFilterStatus := listCmd.String("filter-status", "", "Only show models with this status")
+	listFilterName := listCmd.String("filter-name", "", "Only show models whose name contains this substring")
+	listFilterUser := listCmd.String("filter-user", "", "Only show models downloaded by this user")
 	getModelID := getCmd.String("id", "", "Model ID")
 	downloadModelName := downloadCmd.String("name", "", "Model name from Hugging Face")
+	downloadStream := downloadCmd.Bool("stream", false, "Show a live progress bar via the streaming download endpoint")
 	deleteModelID := deleteCmd.String("id", "", "Model ID to delete")
+	deleteIDs := deleteCmd.String("ids", "", "Comma-separated model IDs to batch delete")
+	deleteAll := deleteCmd.Bool("all", false, "Batch delete every model matching -filter-unused-since/-filter-status")
+	deleteFilterUnusedSince := deleteCmd.String("filter-unused-since", "", "With -all, only delete models unused for this long, e.g. 30d")
+	deleteFilterStatus := deleteCmd.String("filter-status", "", "With -all, only delete models with this status")
+	deleteYes := deleteCmd.Bool("yes", false, "Skip the confirmation prompt")
+	deleteCmd.BoolVar(deleteYes, "y", false, "Shorthand for -yes")
+	deleteDryRun := deleteCmd.Bool("dry-run", false, "Show what would be freed without deleting anything")
+	statsWatch := statsCmd.Bool("watch", false, "Continuously re-query and render a live table")
+	statsInterval := statsCmd.Duration("interval", 5*time.Second, "Poll interval for -watch")
+	statsQuery := statsCmd.String("query", "", "promQL query to evaluate instead of the summary stats (implies -watch support)")
+	exportOutput := exportCmd.String("o", "", "Output archive path")
+	exportOnly := exportCmd.String("only", "", "Comma-separated model names to export (default: all)")
+	exportResume := exportCmd.Bool("resume", false, "Resume a previous interrupted export instead of starting over")
+	importInput := importCmd.String("i", "", "Input archive path")
+	importDryRun := importCmd.Bool("dry-run", false, "Report what would be imported without writing anything")
+	importOnly := importCmd.String("only", "", "Comma-separated model names to import (default: all)")
 
 	if len(os.Args) < 2 {
 		fmt.Println("Model Management Client")
@@ -293,12 +920,31 @@ func main() {
 		fmt.Println("  download      Download a model from Hugging Face")
 		fmt.Println("  delete        Delete a model")
 		fmt.Println("  health        Check server health")
+		fmt.Println("  stats         Show system statistics, or watch a promQL query live")
+		fmt.Println("  export        Export the model registry to an archive")
+		fmt.Println("  import        Import a model registry archive")
 		fmt.Println("\nExamples:")
 		fmt.Println("  client list")
+		fmt.Println("  client list -page 2 -page-size 50 -sort -downloaded_at -filter-status ready")
 		fmt.Println("  client get -id bert-base-uncased")
 		fmt.Println("  client download -name google/flan-t5-small")
+		fmt.Println("  client download -name google/flan-t5-small -stream")
 		fmt.Println("  client delete -id bert-base-uncased")
+		fmt.Println("  client delete -id bert-base-uncased -dry-run")
+		fmt.Println("  client delete -ids bert-base-uncased,gpt2 -y")
+		fmt.Println("  client delete -all -filter-unused-since 30d -y")
 		fmt.Println("  client health")
+		fmt.Println("  client stats --watch --interval 5s --query 'rate(model_requests_total[1m])'")
+		fmt.Println("  client export -o registry.tgz")
+		fmt.Println("  client export -o registry.tgz -resume")
+		fmt.Println("  client import -i registry.tgz --dry-run")
+		fmt.Println("  client list -api-key $MMC_API_KEY")
+		fmt.Println("  client list -profile prod")
+		fmt.Println("\nAuth flags (all commands):")
+		fmt.Println("  -api-key <key>      Static API key, sent as X-API-Key")
+		fmt.Println("  -token-file <path>  Bearer token file, auto-refreshed on 401")
+		fmt.Println("  -config <path>      Config file with server profiles (default ~/.mmc/config.yaml)")
+		fmt.Println("  -profile <name>     Named profile to load from -config")
 		os.Exit(1)
 	}
 
@@ -307,22 +953,33 @@ func main() {
 	switch command {
 	case "list":
 		listCmd.Parse(os.Args[2:])
-		client := NewClient(serverURL)
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
-		models, err := client.ListModels()
+		page, err := client.ListModelsPage(ListModelsOptions{
+			Page:         *listPage,
+			PageSize:     *listPageSize,
+			Sort:         *listSort,
+			Status:       *listFilterStatus,
+			NameContains: *listFilterName,
+			DownloadedBy: *listFilterUser,
+		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if len(models) == 0 {
+		if len(page.Models) == 0 {
 			fmt.Println("No models found")
 			return
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 		fmt.Fprintln(w, "NAME\tSTATUS\tSIZE\tDOWNLOADS\tFIRST BY")
-		for _, model := range models {
+		for _, model := range page.Models {
 			downloads := 0
 			firstBy := "N/A"
 			if model.Stats != nil {
@@ -340,6 +997,7 @@ func main() {
 			)
 		}
 		w.Flush()
+		fmt.Printf("\nPage %d of %d (%d models total)\n", page.Page, page.TotalPages, page.Total)
 
 	case "get":
 		getCmd.Parse(os.Args[2:])
@@ -349,7 +1007,11 @@ func main() {
 			os.Exit(1)
 		}
 
-		client := NewClient(serverURL)
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		model, err := client.GetModel(*getModelID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -384,14 +1046,24 @@ func main() {
 			os.Exit(1)
 		}
 
-		client := NewClient(serverURL)
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		username := getCurrentUsername()
-		
+
 		fmt.Printf("Requesting download of model: %s\n", *downloadModelName)
 		fmt.Printf("Requester: %s\n", username)
-		fmt.Println("This may take a while...")
 
-		model, err := client.DownloadModel(*downloadModelName, username)
+		var model *Model
+
+		if *downloadStream {
+			model, err = downloadModelWithProgressBar(client, *downloadModelName, username)
+		} else {
+			fmt.Println("This may take a while...")
+			model, err = client.DownloadModel(*downloadModelName, username)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -408,34 +1080,164 @@ func main() {
 
 	case "delete":
 		deleteCmd.Parse(os.Args[2:])
-		if *deleteModelID == "" {
-			fmt.Fprintln(os.Stderr, "Error: -id is required")
+
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch {
+		case *deleteAll || *deleteIDs != "":
+			if *deleteDryRun {
+				fmt.Fprintln(os.Stderr, "Error: -dry-run is only supported with -id")
+				os.Exit(1)
+			}
+			runBatchDelete(client, *deleteIDs, *deleteAll, *deleteFilterUnusedSince, *deleteFilterStatus, *deleteYes)
+
+		case *deleteModelID != "":
+			runSingleDelete(client, *deleteModelID, *deleteYes, *deleteDryRun)
+
+		default:
+			fmt.Fprintln(os.Stderr, "Error: one of -id, -ids, or -all is required")
 			deleteCmd.PrintDefaults()
 			os.Exit(1)
 		}
 
-		client := NewClient(serverURL)
-		fmt.Printf("Deleting model: %s\n", *deleteModelID)
+	case "health":
+		healthCmd.Parse(os.Args[2:])
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		err = client.HealthCheck()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Server is healthy!")
 
-		err := client.DeleteModel(*deleteModelID)
+	case "stats":
+		statsCmd.Parse(os.Args[2:])
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		fmt.Println("Model deleted successfully!")
+		if *statsQuery != "" {
+			metricsClient := metrics.NewClient(client.BaseURL, client.HTTPClient, client.Auth)
+			runMetricsWatch(metricsClient, *statsQuery, *statsInterval)
+			return
+		}
 
-	case "health":
-		healthCmd.Parse(os.Args[2:])
-		client := NewClient(serverURL)
+		if !*statsWatch {
+			printSystemStats(client)
+			return
+		}
+
+		for {
+			printSystemStats(client)
+			time.Sleep(*statsInterval)
+		}
+
+	case "export":
+		exportCmd.Parse(os.Args[2:])
+		if *exportOutput == "" {
+			fmt.Fprintln(os.Stderr, "Error: -o is required")
+			exportCmd.PrintDefaults()
+			os.Exit(1)
+		}
 
-		err := client.HealthCheck()
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		opts := ExportOptions{Resume: *exportResume}
+		if *exportOnly != "" {
+			opts.Only = strings.Split(*exportOnly, ",")
+		}
 
-		fmt.Println("Server is healthy!")
+		// Export is always written to a .partial companion file, which is
+		// renamed to -o only on success. That way a pre-existing, already
+		// complete -o file is never at risk of being corrupted, and -resume
+		// has an unambiguous file to resume from.
+		partialPath := *exportOutput + ".partial"
+		openFlags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if *exportResume {
+			openFlags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		f, err := os.OpenFile(partialPath, openFlags, 0644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Exporting registry to %s...\n", *exportOutput)
+		if err := client.ExportRegistry(f, opts); err != nil {
+			f.Close()
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		f.Close()
+
+		if err := os.Rename(partialPath, *exportOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Export complete!")
+
+	case "import":
+		importCmd.Parse(os.Args[2:])
+		if *importInput == "" {
+			fmt.Fprintln(os.Stderr, "Error: -i is required")
+			importCmd.PrintDefaults()
+			os.Exit(1)
+		}
+
+		client, err := clientFromFlags(serverURL, apiKey, tokenFile, configPath, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts := ImportOptions{DryRun: *importDryRun}
+		if *importOnly != "" {
+			opts.Only = strings.Split(*importOnly, ",")
+		}
+
+		f, err := os.Open(*importInput)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		report, err := client.ImportRegistry(f, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if report.DryRun {
+			fmt.Println("Dry run - no changes were made:")
+		} else {
+			fmt.Println("Import complete:")
+		}
+		for _, result := range report.Results {
+			switch {
+			case result.Error != "":
+				fmt.Printf("  %s: error - %s\n", result.Name, result.Error)
+			case result.AlreadyExists:
+				fmt.Printf("  %s: already exists, skipped\n", result.Name)
+			default:
+				fmt.Printf("  %s: imported\n", result.Name)
+			}
+		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
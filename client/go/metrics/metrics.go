@@ -0,0 +1,216 @@
+// Package metrics is a small Prometheus-compatible client for the model
+// management server's /api/metrics endpoints, used by the CLI's
+// `stats --watch` mode to render live operator dashboards.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AuthProvider applies credentials to an outgoing request. It matches the
+// shape of the main CLI's AuthProvider so that its NoAuth/StaticAPIKey/
+// BearerToken implementations can be passed straight through to a Client's
+// Auth field.
+type AuthProvider interface {
+	Apply(req *http.Request) error
+}
+
+// noAuth sends requests without any credentials, the default when a Client
+// is constructed without one
+type noAuth struct{}
+
+func (noAuth) Apply(req *http.Request) error { return nil }
+
+// Client queries the server's Prometheus-shaped metrics endpoints
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Auth       AuthProvider
+}
+
+// NewClient creates a new metrics client. auth may be nil, in which case
+// requests are sent without credentials.
+func NewClient(baseURL string, httpClient *http.Client, auth AuthProvider) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if auth == nil {
+		auth = noAuth{}
+	}
+	return &Client{BaseURL: baseURL, HTTPClient: httpClient, Auth: auth}
+}
+
+// Sample is a single (timestamp, value) pair as returned in the
+// `[unix_ts, "string_val"]` wire format
+type Sample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// UnmarshalJSON parses the `[unix_ts, "string_val"]` pair Prometheus uses
+func (s *Sample) UnmarshalJSON(data []byte) error {
+	var raw [2]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to decode sample: %w", err)
+	}
+
+	ts, ok := raw[0].(float64)
+	if !ok {
+		return fmt.Errorf("expected numeric timestamp, got %T", raw[0])
+	}
+	s.Timestamp = time.Unix(int64(ts), 0)
+
+	valStr, ok := raw[1].(string)
+	if !ok {
+		return fmt.Errorf("expected string value, got %T", raw[1])
+	}
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse sample value %q: %w", valStr, err)
+	}
+	s.Value = val
+
+	return nil
+}
+
+// Scalar is a single instant-vector-less value with no label set
+type Scalar struct {
+	Sample Sample
+}
+
+// UnmarshalJSON parses a bare `[unix_ts, "string_val"]` scalar result
+func (s *Scalar) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.Sample)
+}
+
+// Vector is a list of instant samples, one per label set
+type Vector []struct {
+	Metric map[string]string `json:"metric"`
+	Value  Sample             `json:"value"`
+}
+
+// Matrix is a list of ranges, one per label set, used by QueryMetricsRange
+type Matrix []struct {
+	Metric map[string]string `json:"metric"`
+	Values []Sample           `json:"values"`
+}
+
+// QueryResult is the decoded body of a query or query_range response
+type QueryResult struct {
+	ResultType string          `json:"resultType"`
+	RawResult  json.RawMessage `json:"result"`
+
+	Vector Vector
+	Matrix Matrix
+	Scalar Scalar
+}
+
+// decode unmarshals RawResult into the field matching ResultType
+func (r *QueryResult) decode() error {
+	switch r.ResultType {
+	case "vector":
+		return json.Unmarshal(r.RawResult, &r.Vector)
+	case "matrix":
+		return json.Unmarshal(r.RawResult, &r.Matrix)
+	case "scalar":
+		return json.Unmarshal(r.RawResult, &r.Scalar)
+	default:
+		return fmt.Errorf("unknown metrics result type %q", r.ResultType)
+	}
+}
+
+type queryResponse struct {
+	Success bool        `json:"success"`
+	Error   string      `json:"error,omitempty"`
+	Data    QueryResult `json:"data"`
+}
+
+// QueryMetrics evaluates promQL at a single instant in time
+func (c *Client) QueryMetrics(ctx context.Context, promQL string, ts time.Time) (*QueryResult, error) {
+	query := url.Values{}
+	query.Set("query", promQL)
+	if !ts.IsZero() {
+		query.Set("time", strconv.FormatInt(ts.Unix(), 10))
+	}
+
+	return c.query(ctx, "/api/metrics/query", query)
+}
+
+// QueryMetricsRange evaluates promQL over [start, end] sampled every step
+func (c *Client) QueryMetricsRange(ctx context.Context, promQL string, start, end time.Time, step time.Duration) (*QueryResult, error) {
+	query := url.Values{}
+	query.Set("query", promQL)
+	query.Set("start", strconv.FormatInt(start.Unix(), 10))
+	query.Set("end", strconv.FormatInt(end.Unix(), 10))
+	query.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	return c.query(ctx, "/api/metrics/query_range", query)
+}
+
+func (c *Client) query(ctx context.Context, path string, query url.Values) (*QueryResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := c.Auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed queryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode metrics response: %w", err)
+	}
+
+	if !parsed.Success {
+		return nil, fmt.Errorf("API error: %s", parsed.Error)
+	}
+
+	if err := parsed.Data.decode(); err != nil {
+		return nil, err
+	}
+
+	return &parsed.Data, nil
+}
+
+// Sparkline renders a matrix series as a single-line ASCII sparkline
+func Sparkline(samples []Sample) string {
+	if len(samples) == 0 {
+		return ""
+	}
+
+	blocks := []rune(" ▁▂▃▄▅▆▇█")
+	min, max := samples[0].Value, samples[0].Value
+	for _, s := range samples {
+		if s.Value < min {
+			min = s.Value
+		}
+		if s.Value > max {
+			max = s.Value
+		}
+	}
+
+	spread := max - min
+	out := make([]rune, len(samples))
+	for i, s := range samples {
+		if spread == 0 {
+			out[i] = blocks[len(blocks)/2]
+			continue
+		}
+		idx := int((s.Value - min) / spread * float64(len(blocks)-1))
+		out[i] = blocks[idx]
+	}
+
+	return string(out)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// RegistryManifest is the manifest.json entry at the root of a registry
+// export archive
+type RegistryManifest struct {
+	SchemaVersion int     `json:"schema_version"`
+	ExportedAt    string  `json:"exported_at"`
+	SourceServer  string  `json:"source_server"`
+	Models        []Model `json:"models"`
+}
+
+// ExportOptions controls which models are included in a registry export, and
+// whether the transfer should resume a previous attempt
+type ExportOptions struct {
+	Only []string // model names to include; empty means export everything
+
+	// Resume must be set explicitly to continue a previously interrupted
+	// export; it is never inferred from w already having content, since a
+	// file with content in it may just be a prior *complete* export. When
+	// Resume is true and w is an *os.File, the existing file size is sent
+	// as the start of a Range request; ExportRegistry fails rather than
+	// silently re-downloading the whole archive if the server doesn't honor it.
+	Resume bool
+}
+
+// ImportOptions controls how a registry archive is imported
+type ImportOptions struct {
+	DryRun bool
+	Only   []string // model names to import; empty means import everything in the archive
+}
+
+// ImportModelResult is the outcome of importing a single model from an archive
+type ImportModelResult struct {
+	Name          string `json:"name"`
+	AlreadyExists bool   `json:"already_exists"`
+	Error         string `json:"error,omitempty"`
+}
+
+// ImportReport summarizes the result of ImportRegistry
+type ImportReport struct {
+	DryRun  bool                 `json:"dry_run"`
+	Results []ImportModelResult  `json:"results"`
+}
+
+// ExportRegistry writes a gzipped tar of the server's model registry to w.
+// Pass opts.Resume to continue an interrupted transfer from the last byte
+// already written to w (when w is an *os.File) via an HTTP Range request;
+// without it, every call starts a fresh export from byte zero.
+func (c *Client) ExportRegistry(w io.Writer, opts ExportOptions) error {
+	query := url.Values{}
+	if len(opts.Only) > 0 {
+		query.Set("only", strings.Join(opts.Only, ","))
+	}
+
+	reqURL := c.BaseURL + "/api/registry/export"
+	if encoded := query.Encode(); encoded != "" {
+		reqURL += "?" + encoded
+	}
+
+	var resumeFrom int64
+	if opts.Resume {
+		if f, ok := w.(*os.File); ok {
+			if info, err := f.Stat(); err == nil {
+				resumeFrom = info.Size()
+			}
+		}
+	}
+
+	const maxAttempts = 5
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		req, err := http.NewRequest("GET", reqURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		if resumeFrom > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+		}
+		req.Header.Set("User-Agent", c.UserAgent)
+		if err := c.Auth.Apply(req); err != nil {
+			return fmt.Errorf("failed to apply auth: %w", err)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if attempt == maxAttempts {
+				return fmt.Errorf("failed to export registry: %w", err)
+			}
+			continue
+		}
+
+		if resumeFrom > 0 && resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			return fmt.Errorf("requested a resume from byte %d but the server returned status %d instead of 206 Partial Content; rerun without -resume to start over", resumeFrom, resp.StatusCode)
+		}
+		if resumeFrom == 0 && resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return fmt.Errorf("export failed with status %d", resp.StatusCode)
+		}
+
+		n, copyErr := io.Copy(w, resp.Body)
+		resp.Body.Close()
+		resumeFrom += n
+
+		if copyErr == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			return fmt.Errorf("export interrupted after %d bytes: %w", resumeFrom, copyErr)
+		}
+	}
+
+	return fmt.Errorf("export failed after %d attempts", maxAttempts)
+}
+
+// ImportRegistry reads a registry export archive from r and imports it into
+// the server. When opts.Only is set, the archive is filtered client-side
+// before upload so only the requested models are transferred. Import is
+// idempotent: models whose sha256 already exists on the server are reported
+// via ImportModelResult.AlreadyExists rather than re-downloaded.
+func (c *Client) ImportRegistry(r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	body := r
+	if len(opts.Only) > 0 {
+		filtered, err := filterRegistryArchive(r, opts.Only)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter archive: %w", err)
+		}
+		body = filtered
+	}
+
+	query := url.Values{}
+	if opts.DryRun {
+		query.Set("dry_run", "true")
+	}
+
+	reqURL := c.BaseURL + "/api/registry/import"
+	if encoded := query.Encode(); encoded != "" {
+		reqURL += "?" + encoded
+	}
+
+	req, err := http.NewRequest("POST", reqURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("User-Agent", c.UserAgent)
+	if err := c.Auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import registry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var report ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode import report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// filterRegistryArchive re-packs a registry export archive, keeping only the
+// manifest entry and per-model directories whose name is in names.
+func filterRegistryArchive(r io.Reader, names []string) (io.Reader, error) {
+	keep := make(map[string]bool, len(names))
+	for _, n := range names {
+		keep[n] = true
+	}
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+
+	pr, pw := io.Pipe()
+	gzw := gzip.NewWriter(pw)
+	tw := tar.NewWriter(gzw)
+
+	go func() {
+		var finalErr error
+		defer func() {
+			tw.Close()
+			gzw.Close()
+			pw.CloseWithError(finalErr)
+		}()
+
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				finalErr = fmt.Errorf("failed to read archive entry: %w", err)
+				return
+			}
+
+			if hdr.Name != "manifest.json" {
+				modelName := strings.SplitN(hdr.Name, "/", 2)[0]
+				if !keep[modelName] {
+					continue
+				}
+			}
+
+			if err := tw.WriteHeader(hdr); err != nil {
+				finalErr = fmt.Errorf("failed to write archive entry: %w", err)
+				return
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				finalErr = fmt.Errorf("failed to copy archive entry: %w", err)
+				return
+			}
+		}
+	}()
+
+	return pr, nil
+}